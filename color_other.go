@@ -0,0 +1,9 @@
+//go:build !windows
+
+package logt
+
+import "os"
+
+// enableANSI is a no-op outside Windows: every terminal logt targets there
+// already interprets ANSI escape codes natively.
+func enableANSI(f *os.File) bool { return true }
@@ -0,0 +1,43 @@
+package logt
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsColorCapableNonTTY(t *testing.T) {
+	assert.False(t, isColorCapable(&bytes.Buffer{}), "a non-*os.File writer is never color-capable")
+
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+	assert.False(t, isColorCapable(w), "a pipe is an *os.File but not a terminal")
+}
+
+// TestColorGatingShortVsLong forces colorCapable (isColorCapable can't see a
+// real terminal in a test run) to check what Lshortcolor/Llongcolor actually
+// wrap in escape codes once color is allowed.
+func TestColorGatingShortVsLong(t *testing.T) {
+	var buf bytes.Buffer
+	sl := NewStdLogget()
+	sl.SetOutput(&buf)
+	sl.colorCapable.Store(true)
+
+	sl.SetFlags(Llevel | Lshortcolor)
+	sl.Printf(0, "", "short color line")
+	short := buf.String()
+	assert.True(t, strings.HasPrefix(short, "\x1b["), "Lshortcolor should wrap the level tag in an escape code")
+	assert.True(t, strings.HasSuffix(short, "short color line\n"), "only the tag is colorized, not the message")
+
+	buf.Reset()
+	sl.SetFlags(Llevel | Llongcolor)
+	sl.Printf(0, "", "long color line")
+	long := buf.String()
+	assert.True(t, strings.HasPrefix(long, "\x1b["), "Llongcolor should also wrap the tag, as part of the whole line")
+	assert.True(t, strings.HasSuffix(long, "\x1b[0m\n"), "the reset code should land before the trailing newline, not after it")
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package logt
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableANSI tries to switch f's console into
+// ENABLE_VIRTUAL_TERMINAL_PROCESSING mode, which lets the Windows console
+// host interpret ANSI escape codes instead of printing them literally. It
+// reports whether f ended up in that mode; logt falls back to plain text
+// when it doesn't (older consoles, or f isn't a real console at all).
+func enableANSI(f *os.File) bool {
+	h := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
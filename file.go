@@ -0,0 +1,188 @@
+package logt
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileOutput is an Output that writes to a file, rotating it once it grows
+// past maxSize bytes or gets older than maxAge, keeping at most maxBackups
+// old files (gzip-compressed when compress is set) around; all four are
+// NewFileOutput's parameters. It formats
+// records the same way StdLogget does; only the destination differs.
+type FileOutput struct {
+	*StdLogget
+	rot *rotatingFile
+}
+
+// NewFileOutput opens (creating if necessary) the file at path and rotates
+// it according to maxSize (bytes, 0 disables size-based rotation), maxAge
+// (0 disables age-based rotation) and maxBackups (0 keeps no backups).
+func NewFileOutput(path string, maxSize int64, maxAge time.Duration, maxBackups int, compress bool) (*FileOutput, error) {
+	rot, err := newRotatingFile(path, maxSize, maxAge, maxBackups, compress)
+	if err != nil {
+		return nil, err
+	}
+	sl := NewStdLogget()
+	sl.SetOutput(rot)
+	return &FileOutput{StdLogget: sl, rot: rot}, nil
+}
+
+// Close closes the underlying file.
+func (f *FileOutput) Close() error { return f.rot.Close() }
+
+// rotatingFile is the io.Writer FileOutput hands to StdLogget.SetOutput.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+	file       *os.File
+	size       int64
+	opened     time.Time
+}
+
+func newRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int, compress bool) (*rotatingFile, error) {
+	rf := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		compress:   compress,
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	if dir := filepath.Dir(rf.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.file = f
+	rf.size = info.Size()
+	rf.opened = info.ModTime()
+	return nil
+}
+
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.shouldRotate(len(p)) {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) shouldRotate(next int) bool {
+	if rf.maxSize > 0 && rf.size+int64(next) > rf.maxSize {
+		return true
+	}
+	if rf.maxAge > 0 && time.Since(rf.opened) > rf.maxAge {
+		return true
+	}
+	return false
+}
+
+func (rf *rotatingFile) rotate() error {
+	if rf.file != nil {
+		rf.file.Close()
+	}
+	backup := fmt.Sprintf("%s.%s", rf.path, time.Now().Format("20060102T150405.000000000"))
+	if err := os.Rename(rf.path, backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if rf.compress {
+		if err := gzipFile(backup); err == nil {
+			os.Remove(backup)
+		}
+	}
+	if err := rf.open(); err != nil {
+		return err
+	}
+	return rf.prune()
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return err
+	}
+	return gw.Close()
+}
+
+// prune removes the oldest rotated backups once there are more than
+// maxBackups of them; maxBackups <= 0 means keep none, so every rotated
+// backup is removed. Backup names carry a sortable timestamp suffix, so a
+// lexical sort is also a chronological one.
+func (rf *rotatingFile) prune() error {
+	keep := rf.maxBackups
+	if keep < 0 {
+		keep = 0
+	}
+	dir := filepath.Dir(rf.path)
+	base := filepath.Base(rf.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if name != base && strings.HasPrefix(name, base+".") {
+			backups = append(backups, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(backups)
+	for len(backups) > keep {
+		os.Remove(backups[0])
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.file != nil {
+		return rf.file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package logt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileOutputRotatesAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fo, err := NewFileOutput(path, 64, 0, 2, false)
+	assert.NoError(t, err)
+	defer fo.Close()
+
+	lg := New(fo, "", 0)
+	for i := 0; i < 20; i++ {
+		lg.Println("this is a log line long enough to force rotation")
+	}
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var backups int
+	for _, e := range entries {
+		if e.Name() != "app.log" {
+			backups++
+		}
+	}
+	assert.Greater(t, backups, 0, "writing past maxSize repeatedly should have rotated at least once")
+	assert.LessOrEqual(t, backups, 2, "prune should keep at most maxBackups old files around")
+}
+
+func TestFileOutputZeroMaxBackupsKeepsNone(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fo, err := NewFileOutput(path, 64, 0, 0, false)
+	assert.NoError(t, err)
+	defer fo.Close()
+
+	lg := New(fo, "", 0)
+	for i := 0; i < 20; i++ {
+		lg.Println("this is a log line long enough to force rotation")
+	}
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var backups int
+	for _, e := range entries {
+		if e.Name() != "app.log" {
+			backups++
+		}
+	}
+	assert.Zero(t, backups, "maxBackups == 0 should keep no rotated backups around")
+}
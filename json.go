@@ -0,0 +1,185 @@
+package logt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FieldOutput is implemented by Output backends that natively carry
+// structured key/value context on every record (see Logger.With). Backends
+// that don't implement it get a generic text-appending fallback instead.
+type FieldOutput interface {
+	Output
+	WithField(key string, val interface{}) Output
+}
+
+type field struct {
+	key string
+	val interface{}
+}
+
+// jsonState holds the mutable state shared by a JSONLogget and every child
+// logger derived from it via WithField, so they all write to the same
+// destination under the same flags/prefix.
+type jsonState struct {
+	flag      atomic.Int32
+	prefix    atomic.Pointer[string]
+	outMu     sync.Mutex
+	out       io.Writer
+	isDiscard atomic.Bool
+}
+
+// JSONLogget is an Output that emits one JSON object per record, with
+// fields "ts", "level", "msg", "caller", "func" and an optional "prefix".
+// It's a machine-parseable alternative to the colorized StdLogget:
+//
+//	logt.New(logt.NewJSONLogget(os.Stdout), "", logt.LstdFlags|logt.LCaller)
+type JSONLogget struct {
+	state  *jsonState
+	fields []field
+}
+
+func NewJSONLogget(w io.Writer) *JSONLogget {
+	st := &jsonState{out: w}
+	st.prefix.Store(new(string))
+	return &JSONLogget{state: st}
+}
+
+func (jl *JSONLogget) Flags() (flag int)       { return int(jl.state.flag.Load()) }
+func (jl *JSONLogget) Prefix() (prefix string) { return *jl.state.prefix.Load() }
+func (jl *JSONLogget) SetFlags(flag int)       { jl.state.flag.Store(int32(flag)) }
+func (jl *JSONLogget) SetPrefix(prefix string) { jl.state.prefix.Store(&prefix) }
+
+// SetOutput redirects every logger sharing jl's state to w. Passing
+// io.Discard enables a fast path that skips JSON-encoding entirely.
+func (jl *JSONLogget) SetOutput(w io.Writer) {
+	jl.state.outMu.Lock()
+	jl.state.out = w
+	jl.state.outMu.Unlock()
+	jl.state.isDiscard.Store(w == io.Discard)
+}
+
+// WithField returns a child JSONLogget that merges key/val into every
+// record it emits, in addition to any fields already carried by jl.
+func (jl *JSONLogget) WithField(key string, val interface{}) Output {
+	fields := make([]field, len(jl.fields)+1)
+	copy(fields, jl.fields)
+	fields[len(jl.fields)] = field{key, val}
+	return &JSONLogget{state: jl.state, fields: fields}
+}
+
+func (jl *JSONLogget) Printf(calldepth int, format string, vset ...interface{}) {
+	level := LevelInfo
+	if anyErr(vset...) {
+		level = LevelError
+	}
+	jl.logf(level, calldepth, format, vset...)
+}
+
+func (jl *JSONLogget) Log(level int, calldepth int, format string, vset ...interface{}) {
+	jl.logf(level, calldepth, format, vset...)
+}
+
+type jsonRecord struct {
+	Time   string                 `json:"ts"`
+	Level  string                 `json:"level"`
+	Msg    string                 `json:"msg"`
+	Caller string                 `json:"caller,omitempty"`
+	Func   string                 `json:"func,omitempty"`
+	Prefix string                 `json:"prefix,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+func (jl *JSONLogget) logf(level int, calldepth int, format string, vset ...interface{}) {
+	if jl.state.isDiscard.Load() {
+		return
+	}
+
+	var msg string
+	if format == "" {
+		msg = fmt.Sprint(vset...)
+	} else {
+		msg = fmt.Sprintf(format, vset...)
+	}
+
+	rec := jsonRecord{
+		Time:   time.Now().Format(time.RFC3339Nano),
+		Level:  levelName(level),
+		Msg:    msg,
+		Prefix: jl.Prefix(),
+	}
+
+	if flag := jl.Flags(); flag&(LCaller|Lshortfile|Llongfile) != 0 {
+		funcName, fileName, fileLine, fileErr := here(logfBaseDepth + calldepth)
+		if fileErr == nil {
+			rec.Caller = fmt.Sprintf("%s:%d", fileName, fileLine)
+			rec.Func = funcName
+		}
+	}
+
+	if len(jl.fields) > 0 {
+		rec.Fields = make(map[string]interface{}, len(jl.fields))
+		for _, f := range jl.fields {
+			rec.Fields[f.key] = f.val
+		}
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := json.NewEncoder(buf).Encode(rec); err != nil {
+		return
+	}
+
+	jl.state.outMu.Lock()
+	jl.state.out.Write(buf.Bytes())
+	jl.state.outMu.Unlock()
+}
+
+// fieldFallback decorates an Output that doesn't implement FieldOutput by
+// appending "key=val" pairs to the formatted message, so Logger.With still
+// works uniformly across every backend.
+type fieldFallback struct {
+	Output
+	fields []field
+}
+
+func newFieldFallback(out Output, key string, val interface{}) *fieldFallback {
+	if ff, ok := out.(*fieldFallback); ok {
+		fields := make([]field, len(ff.fields)+1)
+		copy(fields, ff.fields)
+		fields[len(ff.fields)] = field{key, val}
+		return &fieldFallback{Output: ff.Output, fields: fields}
+	}
+	return &fieldFallback{Output: out, fields: []field{{key, val}}}
+}
+
+func (f *fieldFallback) suffix() string {
+	var b strings.Builder
+	for _, fld := range f.fields {
+		fmt.Fprintf(&b, " %s=%v", fld.key, fld.val)
+	}
+	return b.String()
+}
+
+func (f *fieldFallback) render(format string, vset []interface{}) (string, []interface{}) {
+	args := append(append([]interface{}{}, vset...), f.suffix())
+	if format == "" {
+		return "", args
+	}
+	return format + "%s", args
+}
+
+func (f *fieldFallback) Printf(calldepth int, format string, vset ...interface{}) {
+	format, args := f.render(format, vset)
+	f.Output.Printf(calldepth+1, format, args...)
+}
+
+func (f *fieldFallback) Log(level int, calldepth int, format string, vset ...interface{}) {
+	format, args := f.render(format, vset)
+	f.Output.Log(level, calldepth+1, format, args...)
+}
@@ -0,0 +1,33 @@
+package logt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerWithFieldOutput(t *testing.T) {
+	var buf bytes.Buffer
+	jl := NewJSONLogget(&buf)
+	lg := New(jl, "", 0)
+
+	lg.With("reqID", "abc123").Info("hello")
+
+	out := buf.String()
+	assert.Contains(t, out, `"msg":"hello"`)
+	assert.Contains(t, out, `"reqID":"abc123"`)
+}
+
+func TestLoggerWithFallback(t *testing.T) {
+	var buf bytes.Buffer
+	sl := NewStdLogget()
+	sl.SetOutput(&buf)
+	lg := New(sl, "", 0)
+
+	lg.With("reqID", "abc123").Info("hello")
+
+	out := buf.String()
+	assert.Contains(t, out, "hello")
+	assert.Contains(t, out, "reqID=abc123")
+}
@@ -1,35 +1,98 @@
 package logt
 
 import (
-	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 )
 
 // Same as flags in log package, except for LCaller
 const (
-	Ldate         = 1 << iota     // the date in the local time zone: 2009/01/23
-	Ltime                         // the time in the local time zone: 01:23:23
-	Lmicroseconds                 // microsecond resolution: 01:23:23.123123.  assumes Ltime.
-	Llongfile                     // full file name and line number: /a/b/c/d.go:23
-	Lshortfile                    // final file name element and line number: d.go:23. overrides Llongfile
-	LUTC                          // if Ldate or Ltime is set, use UTC rather than the local time zone
-	LCaller                       // package/file-name.go:file-line func-name()
-	LstdFlags     = Ldate | Ltime // initial values for the standard logger
+	Ldate         = 1 << iota              // the date in the local time zone: 2009/01/23
+	Ltime                                  // the time in the local time zone: 01:23:23
+	Lmicroseconds                          // microsecond resolution: 01:23:23.123123.  assumes Ltime.
+	Llongfile                              // full file name and line number: /a/b/c/d.go:23
+	Lshortfile                             // final file name element and line number: d.go:23. overrides Llongfile
+	LUTC                                   // if Ldate or Ltime is set, use UTC rather than the local time zone
+	LCaller                                // package/file-name.go:file-line func-name()
+	Llevel                                 // render the level tag, e.g. [info ] / [error]
+	Lshortcolor                            // colorize just the level tag; no effect without Llevel
+	Llongcolor                             // colorize the whole line, up to the trailing newline
+	LstdFlags     = Ldate | Ltime | Llevel // the standard logger starts with these plus LCaller
 )
 
+// Log levels, from least to most severe. Used with SetLevel/Level and the
+// level-aware helpers (Debug, Info, Warn, Error, Panic, Fatal).
+const (
+	LevelDebug = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelPanic
+	LevelFatal
+)
+
+// levelTag returns the fixed-width tag rendered for a level when Llevel is set.
+func levelTag(level int) string {
+	switch level {
+	case LevelDebug:
+		return "[debug]"
+	case LevelWarn:
+		return "[warn ]"
+	case LevelError:
+		return "[error]"
+	case LevelPanic:
+		return "[panic]"
+	case LevelFatal:
+		return "[fatal]"
+	default:
+		return "[info ]"
+	}
+}
+
+// levelName returns the lowercase name of level, for backends (e.g.
+// JSONLogget) that render it as a plain field rather than a fixed-width tag.
+func levelName(level int) string {
+	switch level {
+	case LevelDebug:
+		return "debug"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	case LevelPanic:
+		return "panic"
+	case LevelFatal:
+		return "fatal"
+	default:
+		return "info"
+	}
+}
+
 type Output interface {
-	// Printf format can be empty string "" which means no formatting
-	Printf(format string, vset ...interface{})
+	// Printf format can be empty string "" which means no formatting.
+	// calldepth behaves exactly like Log's below.
+	Printf(calldepth int, format string, vset ...interface{})
+	// Log behaves like Printf but carries an explicit level, used for
+	// filtering and for choosing the rendered tag/color, and a calldepth:
+	// the number of frames to ascend from Log itself when resolving
+	// LCaller/Lshortfile/Llongfile (1 is Log's immediate caller). callerDepth/printfCallerDepth
+	// are the right values when Log/Printf are called straight from a Logger
+	// method; anything that interposes extra frames (a MultiOutput fan-out,
+	// a fields decorator, a user's own wrapper) must add 1 per frame it
+	// introduces.
+	Log(level int, calldepth int, format string, vset ...interface{})
 	Flags() (flag int)
 	Prefix() (prefix string)
 	SetFlags(flag int)
@@ -38,7 +101,8 @@ type Output interface {
 
 // Logger .
 type Logger struct {
-	out Output
+	out   Output
+	level int
 }
 
 // New creates a new *Logger
@@ -50,73 +114,245 @@ func New(out Output, prefix string, flag int) *Logger {
 	}
 }
 
+// callerDepth is the calldepth an Output.Log call needs, when made directly
+// from a Logger convenience method (log's own caller), to have
+// LCaller/Lshortfile/Llongfile resolve to whoever called that method.
+const callerDepth = 3
+
+// printfCallerDepth is the calldepth an Output.Printf call needs when made
+// directly from Logger.Print/Printf/Println: one fewer frame than
+// callerDepth, since those methods call Output.Printf directly instead of
+// going through Logger.log.
+const printfCallerDepth = callerDepth - 1
+
+// log calls out.Log(level, ...) unless level is below the logger's
+// threshold. calldepth is forwarded to Output.Log verbatim; pass
+// callerDepth from a direct Logger method, and callerDepth+N from
+// something that interposes N extra frames above that.
+func (l *Logger) log(level int, calldepth int, format string, v ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.out.Log(level, calldepth, format, v...)
+}
+
 func (l *Logger) Fatal(v ...interface{}) {
-	l.out.Printf("", v...)
+	l.log(LevelFatal, callerDepth, "", v...)
 	os.Exit(1)
 }
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.out.Printf(format, v...)
+	l.log(LevelFatal, callerDepth, format, v...)
 	os.Exit(1)
 }
 func (l *Logger) Fatalln(v ...interface{}) {
 	v = append(v, "\n")
-	l.out.Printf("", v...)
+	l.log(LevelFatal, callerDepth, "", v...)
 	os.Exit(1)
 }
 
 func (l *Logger) Panic(v ...interface{}) {
-	l.out.Printf("", v...)
+	l.log(LevelPanic, callerDepth, "", v...)
 	panic(fmt.Sprint(v...))
 }
 func (l *Logger) Panicf(format string, v ...interface{}) {
-	l.out.Printf(format, v...)
+	l.log(LevelPanic, callerDepth, format, v...)
 	panic(fmt.Sprintf(format, v...))
 }
 func (l *Logger) Panicln(v ...interface{}) {
 	v = append(v, "\n")
-	l.out.Printf("", v...)
+	l.log(LevelPanic, callerDepth, "", v...)
 	panic(fmt.Sprint(v...))
 }
 
+func (l *Logger) Debug(v ...interface{}) { l.log(LevelDebug, callerDepth, "", v...) }
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.log(LevelDebug, callerDepth, format, v...)
+}
+func (l *Logger) Debugln(v ...interface{}) {
+	v = append(v, "\n")
+	l.log(LevelDebug, callerDepth, "", v...)
+}
+
+func (l *Logger) Info(v ...interface{}) { l.log(LevelInfo, callerDepth, "", v...) }
+func (l *Logger) Infof(format string, v ...interface{}) {
+	l.log(LevelInfo, callerDepth, format, v...)
+}
+func (l *Logger) Infoln(v ...interface{}) {
+	v = append(v, "\n")
+	l.log(LevelInfo, callerDepth, "", v...)
+}
+
+func (l *Logger) Warn(v ...interface{}) { l.log(LevelWarn, callerDepth, "", v...) }
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.log(LevelWarn, callerDepth, format, v...)
+}
+func (l *Logger) Warnln(v ...interface{}) {
+	v = append(v, "\n")
+	l.log(LevelWarn, callerDepth, "", v...)
+}
+
+func (l *Logger) Error(v ...interface{}) { l.log(LevelError, callerDepth, "", v...) }
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	l.log(LevelError, callerDepth, format, v...)
+}
+func (l *Logger) Errorln(v ...interface{}) {
+	v = append(v, "\n")
+	l.log(LevelError, callerDepth, "", v...)
+}
+
+// printfLevel is the implicit level of a Print/Printf/Println call: the
+// same anyErr heuristic every Output.Printf already uses for tag/color,
+// reused here so SetLevel also gates these calls the way Debug/Info/Warn/
+// Error already are.
+func printfLevel(v ...interface{}) int {
+	if anyErr(v...) {
+		return LevelError
+	}
+	return LevelInfo
+}
+
 func (l *Logger) Print(v ...interface{}) {
-	l.out.Printf("", v...)
+	if printfLevel(v...) < l.level {
+		return
+	}
+	l.out.Printf(printfCallerDepth, "", v...)
 }
 func (l *Logger) Printf(format string, v ...interface{}) {
-	l.out.Printf(format, v...)
+	if printfLevel(v...) < l.level {
+		return
+	}
+	l.out.Printf(printfCallerDepth, format, v...)
 }
 func (l *Logger) Println(v ...interface{}) {
 	v = append(v, "\n")
-	l.out.Printf("", v...)
+	if printfLevel(v...) < l.level {
+		return
+	}
+	l.out.Printf(printfCallerDepth, "", v...)
 }
 
 func (l *Logger) SetFlags(flag int)       { l.out.SetFlags(flag) }
 func (l *Logger) SetOutput(out Output)    { l.out = out }
 func (l *Logger) SetPrefix(prefix string) { l.out.SetPrefix(prefix) }
+func (l *Logger) SetLevel(level int)      { l.level = level }
 
 func (l *Logger) Prefix() string { return l.out.Prefix() }
 func (l *Logger) Flags() int     { return l.out.Flags() }
+func (l *Logger) Level() int     { return l.level }
+
+// With returns a child *Logger that merges key/val into every record it
+// emits. Backends that implement FieldOutput (e.g. JSONLogget) carry the
+// pair as a real structured field; other backends fall back to appending
+// "key=val" to the formatted message.
+func (l *Logger) With(key string, val interface{}) *Logger {
+	var out Output
+	if fo, ok := l.out.(FieldOutput); ok {
+		out = fo.WithField(key, val)
+	} else {
+		out = newFieldFallback(l.out, key, val)
+	}
+	return &Logger{out: out, level: l.level}
+}
+
+// Output writes s as a single record (bypassing Printf's heuristic and any
+// level filtering), resolving the caller calldepth frames above its own
+// immediate caller. Pass 0 for the common case of calling Output directly;
+// add 1 for every helper you wrap it in. This lets library authors adapt
+// logt behind their own logging API without losing accurate file:line info.
+func (l *Logger) Output(calldepth int, s string) error {
+	l.out.Log(LevelInfo, calldepth+callerDepth-1, "", s)
+	return nil
+}
 
-// func (l *Logger) Output(calldepth int, s string) error
+var std = New(NewStdLogget(), "", LCaller|Ldate|Ltime|Llevel)
 
-var std = New(NewStdLogget(), "", LCaller|Ldate|Ltime)
+// The package-level helpers below call std.log (or std.out.Log) directly
+// instead of the corresponding Logger method, so they add exactly one
+// wrapper frame over a direct lg.Debug()-style call rather than two -
+// callerDepth resolves correctly for either call style.
+
+func Fatal(v ...interface{}) {
+	std.log(LevelFatal, callerDepth, "", v...)
+	os.Exit(1)
+}
+func Fatalf(format string, v ...interface{}) {
+	std.log(LevelFatal, callerDepth, format, v...)
+	os.Exit(1)
+}
+func Fatalln(v ...interface{}) {
+	v = append(v, "\n")
+	std.log(LevelFatal, callerDepth, "", v...)
+	os.Exit(1)
+}
+
+func Panic(v ...interface{}) {
+	std.log(LevelPanic, callerDepth, "", v...)
+	panic(fmt.Sprint(v...))
+}
+func Panicf(format string, v ...interface{}) {
+	std.log(LevelPanic, callerDepth, format, v...)
+	panic(fmt.Sprintf(format, v...))
+}
+func Panicln(v ...interface{}) {
+	v = append(v, "\n")
+	std.log(LevelPanic, callerDepth, "", v...)
+	panic(fmt.Sprint(v...))
+}
 
-func Fatal(v ...interface{})                 { std.Fatal(v...) }
-func Fatalf(format string, v ...interface{}) { std.Fatalf(format, v...) }
-func Fatalln(v ...interface{})               { std.Fatalln(v...) }
-func Panic(v ...interface{})                 { std.Panic(v...) }
-func Panicf(format string, v ...interface{}) { std.Panicf(format, v...) }
-func Panicln(v ...interface{})               { std.Panicln(v...) }
 func Print(v ...interface{})                 { std.Print(v...) }
 func Printf(format string, v ...interface{}) { std.Printf(format, v...) }
 func Println(v ...interface{})               { std.Println(v...) }
 
+func Debug(v ...interface{})                 { std.log(LevelDebug, callerDepth, "", v...) }
+func Debugf(format string, v ...interface{}) { std.log(LevelDebug, callerDepth, format, v...) }
+func Debugln(v ...interface{}) {
+	v = append(v, "\n")
+	std.log(LevelDebug, callerDepth, "", v...)
+}
+func Info(v ...interface{})                 { std.log(LevelInfo, callerDepth, "", v...) }
+func Infof(format string, v ...interface{}) { std.log(LevelInfo, callerDepth, format, v...) }
+func Infoln(v ...interface{}) {
+	v = append(v, "\n")
+	std.log(LevelInfo, callerDepth, "", v...)
+}
+func Warn(v ...interface{})                 { std.log(LevelWarn, callerDepth, "", v...) }
+func Warnf(format string, v ...interface{}) { std.log(LevelWarn, callerDepth, format, v...) }
+func Warnln(v ...interface{}) {
+	v = append(v, "\n")
+	std.log(LevelWarn, callerDepth, "", v...)
+}
+func Error(v ...interface{})                 { std.log(LevelError, callerDepth, "", v...) }
+func Errorf(format string, v ...interface{}) { std.log(LevelError, callerDepth, format, v...) }
+func Errorln(v ...interface{}) {
+	v = append(v, "\n")
+	std.log(LevelError, callerDepth, "", v...)
+}
+
 func Flags() int              { return std.Flags() }
 func Prefix() string          { return std.Prefix() }
 func SetFlags(flag int)       { std.SetFlags(flag) }
 func SetPrefix(prefix string) { std.SetPrefix(prefix) }
+func SetLevel(level int)      { std.SetLevel(level) }
+func Level() int              { return std.Level() }
 
-// func SetOutput(w io.Writer)
-// func Output(calldepth int, s string) error
+// writerSetter is implemented by Output backends that can redirect their
+// destination writer: StdLogget, JSONLogget and FileOutput (via its
+// embedded *StdLogget).
+type writerSetter interface {
+	SetOutput(w io.Writer)
+}
+
+// SetOutput redirects std's destination writer, if its current Output
+// backend supports it (see writerSetter); it's a no-op otherwise.
+func SetOutput(w io.Writer) {
+	if ws, ok := std.out.(writerSetter); ok {
+		ws.SetOutput(w)
+	}
+}
+
+// There is no package-level Output function to go with Logger.Output above:
+// the Output interface already owns that identifier at package scope, so
+// std.Output(calldepth, s) is the way to get stdlib log.Output's behavior.
 
 //-----------------------------------------------------------------------------
 // std output
@@ -149,20 +385,58 @@ func here(skip ...int) (funcName, fileName string, fileLine int, callerErr error
 	return
 }
 
-// StdLogget do not call SetFlags or SetPrefix concurrently
+// StdLogget is safe for concurrent use: SetFlags/SetPrefix/SetOutput/Printf/Log
+// may all be called from multiple goroutines. Flags and prefix are stored
+// atomically and the header for each record is formatted into a pooled buffer
+// without holding any lock; outMu is only held around the final write to out,
+// which is what keeps concurrent lines from interleaving.
 type StdLogget struct {
-	prefix string
-	flag   int
+	flag         atomic.Int32
+	prefix       atomic.Pointer[string]
+	outMu        sync.Mutex
+	out          io.Writer
+	isDiscard    atomic.Bool
+	colorCapable atomic.Bool
 }
 
 func NewStdLogget() *StdLogget {
-	return &StdLogget{}
+	sl := &StdLogget{out: os.Stdout}
+	sl.prefix.Store(new(string))
+	sl.colorCapable.Store(isColorCapable(os.Stdout))
+	return sl
+}
+
+func (sl *StdLogget) Flags() (flag int)       { return int(sl.flag.Load()) }
+func (sl *StdLogget) Prefix() (prefix string) { return *sl.prefix.Load() }
+func (sl *StdLogget) SetFlags(flag int)       { sl.flag.Store(int32(flag)) }
+func (sl *StdLogget) SetPrefix(prefix string) { sl.prefix.Store(&prefix) }
+
+// SetOutput redirects sl's destination writer. Passing io.Discard enables a
+// fast path that skips formatting entirely.
+func (sl *StdLogget) SetOutput(w io.Writer) {
+	sl.outMu.Lock()
+	sl.out = w
+	sl.outMu.Unlock()
+	sl.isDiscard.Store(w == io.Discard)
+	sl.colorCapable.Store(isColorCapable(w))
 }
 
-func (sl *StdLogget) Flags() (flag int)       { return sl.flag }
-func (sl *StdLogget) Prefix() (prefix string) { return sl.prefix }
-func (sl *StdLogget) SetFlags(flag int)       { sl.flag = flag }
-func (sl *StdLogget) SetPrefix(prefix string) { sl.prefix = prefix }
+// isColorCapable reports whether w is a terminal ANSI escape codes can
+// safely be written to: a real console, and (on Windows) one whose console
+// mode was successfully switched into ANSI-processing mode via enableANSI.
+// Anything else (a file, a pipe, a bytes.Buffer, CI log capture) gets plain
+// text regardless of Lshortcolor/Llongcolor, so redirected output stays
+// clean.
+func isColorCapable(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if !isatty.IsTerminal(f.Fd()) && !isatty.IsCygwinTerminal(f.Fd()) {
+		return false
+	}
+	return enableANSI(f)
+}
 
 var bufferPool = sync.Pool{
 	New: func() interface{} {
@@ -190,51 +464,91 @@ func anyErr(v ...interface{}) bool {
 	return false
 }
 
-func (sl *StdLogget) Printf(format string, vset ...interface{}) {
+func (sl *StdLogget) Printf(calldepth int, format string, vset ...interface{}) {
+	level := LevelInfo
+	if anyErr(vset...) {
+		level = LevelError
+	}
+	sl.logf(level, calldepth, format, vset...)
+}
+
+func (sl *StdLogget) Log(level int, calldepth int, format string, vset ...interface{}) {
+	sl.logf(level, calldepth, format, vset...)
+}
+
+// colorized returns a *color.Color for level with fatih/color's own
+// (stdout-only) NoColor auto-detection overridden: isColorCapable already
+// decided, per destination writer, whether ANSI is safe to emit here, so
+// that decision must not be second-guessed again against os.Stdout.
+func colorized(level int) *color.Color {
+	c := color.New(levelColor(level))
+	c.EnableColor()
+	return c
+}
+
+// levelColor picks the color attribute fatih/color uses to render a level's tag.
+func levelColor(level int) color.Attribute {
+	switch level {
+	case LevelDebug:
+		return color.FgBlue
+	case LevelWarn:
+		return color.FgYellow
+	case LevelError:
+		return color.FgRed
+	case LevelPanic, LevelFatal:
+		return color.FgMagenta
+	default:
+		return color.FgGreen
+	}
+}
+
+// logfBaseDepth is the number of frames between here() and whatever called
+// Printf/Log (logf itself, plus Printf/Log); calldepth is added on top.
+const logfBaseDepth = 2
+
+func (sl *StdLogget) logf(level int, calldepth int, format string, vset ...interface{}) {
+	if sl.isDiscard.Load() {
+		return
+	}
+
 	buf := getBuffer()
 	defer putBuffer(buf)
-	defer func() {
-		var ln string
-		if format == "" {
-			ln = fmt.Sprint(vset...)
-		} else {
-			ln = fmt.Sprintf(format, vset...)
-		}
-		fmt.Printf("%s %s", buf.Bytes(), ln)
-	}()
 
-	w := bufio.NewWriter(buf)
-	defer w.Flush()
+	flag := sl.Flags()
+	shortColor := flag&Lshortcolor != 0 && sl.colorCapable.Load()
+	longColor := flag&Llongcolor != 0 && sl.colorCapable.Load()
 
-	if anyErr(vset...) {
-		color.New(color.FgHiRed).Fprintf(w, "[error]")
-	} else {
-		color.New(color.FgHiBlue).Fprintf(w, "[info ]")
+	if flag&Llevel != 0 {
+		if shortColor {
+			colorized(level).Fprint(buf, levelTag(level))
+		} else {
+			buf.WriteString(levelTag(level))
+		}
 	}
 
-	if sl.flag&(Ldate|Ltime|Lmicroseconds) != 0 {
+	if flag&(Ldate|Ltime|Lmicroseconds) != 0 {
 		dtFormat := ""
 		dt := time.Now()
-		if sl.flag&LUTC != 0 {
+		if flag&LUTC != 0 {
 			dt = dt.UTC()
 		}
-		if sl.flag&Ldate != 0 {
+		if flag&Ldate != 0 {
 			dtFormat += "2006/01/02 "
 		}
-		if sl.flag&Ltime != 0 {
+		if flag&Ltime != 0 {
 			dtFormat += "15:04:05"
-		} else if sl.flag&Lmicroseconds != 0 {
+		} else if flag&Lmicroseconds != 0 {
 			dtFormat += "15:04:05.000000"
 		}
-		fmt.Fprintf(w, " %v", dt.Format(strings.TrimSpace(dtFormat)))
+		fmt.Fprintf(buf, " %v", dt.Format(strings.TrimSpace(dtFormat)))
 	}
 
-	if sl.flag&(LCaller|Lshortfile|Llongfile) != 0 {
-		funcName, fileName, fileLine, fileErr := here(4)
+	if flag&(LCaller|Lshortfile|Llongfile) != 0 {
+		funcName, fileName, fileLine, fileErr := here(logfBaseDepth + calldepth)
 		if fileErr == nil {
-			if sl.flag&LCaller != 0 {
-				fmt.Fprintf(w, " %s:%02d %s()", fileName, fileLine, funcName)
-			} else if sl.flag&Lshortfile != 0 {
+			if flag&LCaller != 0 {
+				fmt.Fprintf(buf, " %s:%02d %s()", fileName, fileLine, funcName)
+			} else if flag&Lshortfile != 0 {
 				short := fileName
 				for i := len(fileName) - 1; i > 0; i-- {
 					if fileName[i] == '/' {
@@ -242,9 +556,30 @@ func (sl *StdLogget) Printf(format string, vset ...interface{}) {
 						break
 					}
 				}
-				fileName = " " + short
-				fmt.Fprintf(w, fileName)
+				fmt.Fprintf(buf, " %s", short)
 			}
 		}
 	}
+
+	buf.WriteByte(' ')
+	if format == "" {
+		buf.WriteString(fmt.Sprint(vset...))
+	} else {
+		fmt.Fprintf(buf, format, vset...)
+	}
+	if buf.Len() == 0 || buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+
+	if longColor {
+		raw := strings.TrimSuffix(buf.String(), "\n")
+		line := colorized(level).Sprint(raw)
+		buf.Reset()
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	sl.outMu.Lock()
+	sl.out.Write(buf.Bytes())
+	sl.outMu.Unlock()
 }
@@ -0,0 +1,115 @@
+package logt
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// multiSink pairs a fan-out Output with its own minimum level, so a
+// MultiOutput can, say, send debug-and-up to a file but only warnings and
+// above to syslog. level is an atomic.Int32 rather than plain int because
+// dispatch reads it without holding m.mu (only the sink slice itself is
+// guarded), while SetSinkLevel can be called concurrently with dispatch.
+type multiSink struct {
+	out   Output
+	level atomic.Int32
+}
+
+// MultiOutput is an Output that fans every record out to any number of
+// other Output sinks. Each sink keeps its own Flags/Prefix (SetFlags and
+// SetPrefix on the MultiOutput just broadcast to all of them) and an
+// independent minimum level set via SetSinkLevel; a write failure or panic
+// in one sink does not stop the others from receiving the record.
+type MultiOutput struct {
+	mu    sync.RWMutex
+	sinks []*multiSink
+}
+
+// NewMultiOutput fans out to outs, in the given order. Use SetSinkLevel to
+// give a sink its own threshold; by default every sink receives everything.
+func NewMultiOutput(outs ...Output) *MultiOutput {
+	m := &MultiOutput{sinks: make([]*multiSink, len(outs))}
+	for i, o := range outs {
+		m.sinks[i] = &multiSink{out: o}
+	}
+	return m
+}
+
+// SetSinkLevel sets the minimum level sink i (in NewMultiOutput's order)
+// will forward; records below it are dropped for that sink only.
+func (m *MultiOutput) SetSinkLevel(i int, level int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i >= 0 && i < len(m.sinks) {
+		m.sinks[i].level.Store(int32(level))
+	}
+}
+
+func (m *MultiOutput) Flags() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.sinks) == 0 {
+		return 0
+	}
+	return m.sinks[0].out.Flags()
+}
+
+func (m *MultiOutput) Prefix() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.sinks) == 0 {
+		return ""
+	}
+	return m.sinks[0].out.Prefix()
+}
+
+func (m *MultiOutput) SetFlags(flag int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, s := range m.sinks {
+		s.out.SetFlags(flag)
+	}
+}
+
+func (m *MultiOutput) SetPrefix(prefix string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, s := range m.sinks {
+		s.out.SetPrefix(prefix)
+	}
+}
+
+func (m *MultiOutput) Printf(calldepth int, format string, vset ...interface{}) {
+	level := LevelInfo
+	if anyErr(vset...) {
+		level = LevelError
+	}
+	// +4 for the frames between here and the sink's Printf call: this
+	// closure, emitSink, dispatch, and Printf itself.
+	m.dispatch(level, func(s *multiSink) { s.out.Printf(calldepth+4, format, vset...) })
+}
+
+func (m *MultiOutput) Log(level int, calldepth int, format string, vset ...interface{}) {
+	// +4 for the frames between here and the sink's Log call: this closure,
+	// emitSink, dispatch, and Log itself.
+	m.dispatch(level, func(s *multiSink) { s.out.Log(level, calldepth+4, format, vset...) })
+}
+
+func (m *MultiOutput) dispatch(level int, emit func(*multiSink)) {
+	m.mu.RLock()
+	sinks := m.sinks
+	m.mu.RUnlock()
+	for _, s := range sinks {
+		if level < int(s.level.Load()) {
+			continue
+		}
+		emitSink(s, emit)
+	}
+}
+
+// emitSink runs emit for s behind a recover, so a panicking sink can't take
+// the rest of the fan-out down with it.
+func emitSink(s *multiSink, emit func(*multiSink)) {
+	defer func() { recover() }()
+	emit(s)
+}
@@ -0,0 +1,57 @@
+package logt
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiOutputSinkLevels(t *testing.T) {
+	var bufAll, bufWarnUp bytes.Buffer
+	slAll := NewStdLogget()
+	slAll.SetOutput(&bufAll)
+	slWarnUp := NewStdLogget()
+	slWarnUp.SetOutput(&bufWarnUp)
+
+	m := NewMultiOutput(slAll, slWarnUp)
+	m.SetSinkLevel(1, LevelWarn)
+
+	lg := New(m, "", 0)
+	lg.Debug("debug msg")
+	lg.Warn("warn msg")
+
+	assert.Contains(t, bufAll.String(), "debug msg")
+	assert.Contains(t, bufAll.String(), "warn msg")
+	assert.NotContains(t, bufWarnUp.String(), "debug msg")
+	assert.Contains(t, bufWarnUp.String(), "warn msg")
+}
+
+// TestMultiOutputSetSinkLevelConcurrent exercises SetSinkLevel racing against
+// dispatch's read of the same level (run with -race): the level field must
+// not be touched unguarded on either side.
+func TestMultiOutputSetSinkLevelConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	sl := NewStdLogget()
+	sl.SetOutput(&buf)
+
+	m := NewMultiOutput(sl)
+	lg := New(m, "", 0)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			m.SetSinkLevel(0, i%2)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			lg.Info("hello")
+		}
+	}()
+	wg.Wait()
+}
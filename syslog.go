@@ -0,0 +1,134 @@
+package logt
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Syslog facility codes from RFC 5424 section 6.2.1; only the ones an
+// application is likely to pick are named here, anything else can be
+// passed as a raw int.
+const (
+	SyslogFacilityUser   = 1
+	SyslogFacilityLocal0 = 16
+	SyslogFacilityLocal1 = 17
+)
+
+// syslogSeverity maps a logt level to an RFC 5424 severity (section 6.2.1).
+func syslogSeverity(level int) int {
+	switch level {
+	case LevelDebug:
+		return 7
+	case LevelWarn:
+		return 4
+	case LevelError:
+		return 3
+	case LevelPanic:
+		return 2
+	case LevelFatal:
+		return 1
+	default:
+		return 6
+	}
+}
+
+// SyslogOutput is an Output that sends records to a syslog daemon: the
+// local /dev/log socket (NewLocalSyslogOutput) or a remote collector
+// speaking RFC 5424 over UDP/TCP (NewSyslogOutput). A write failure on the
+// underlying connection is reported to stderr and otherwise swallowed, so
+// it never stops the other sinks in a MultiOutput fan-out.
+type SyslogOutput struct {
+	flag     atomic.Int32
+	prefix   atomic.Pointer[string]
+	mu       sync.Mutex
+	conn     net.Conn
+	network  string // "" for the local unix socket, else "udp"/"tcp"
+	facility int
+	appName  string
+	hostname string
+}
+
+// NewLocalSyslogOutput dials the local /dev/log unix socket.
+func NewLocalSyslogOutput(appName string, facility int) (*SyslogOutput, error) {
+	conn, err := net.Dial("unixgram", "/dev/log")
+	if err != nil {
+		return nil, err
+	}
+	return newSyslogOutput(conn, "", appName, facility), nil
+}
+
+// NewSyslogOutput dials a remote syslog collector over network ("udp" or
+// "tcp") and frames every record as an RFC 5424 message.
+func NewSyslogOutput(network, addr, appName string, facility int) (*SyslogOutput, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return newSyslogOutput(conn, network, appName, facility), nil
+}
+
+func newSyslogOutput(conn net.Conn, network, appName string, facility int) *SyslogOutput {
+	hostname, _ := os.Hostname()
+	so := &SyslogOutput{
+		conn:     conn,
+		network:  network,
+		appName:  appName,
+		facility: facility,
+		hostname: hostname,
+	}
+	so.prefix.Store(new(string))
+	return so
+}
+
+func (so *SyslogOutput) Flags() (flag int)       { return int(so.flag.Load()) }
+func (so *SyslogOutput) Prefix() (prefix string) { return *so.prefix.Load() }
+func (so *SyslogOutput) SetFlags(flag int)       { so.flag.Store(int32(flag)) }
+func (so *SyslogOutput) SetPrefix(prefix string) { so.prefix.Store(&prefix) }
+
+// Close releases the underlying syslog connection.
+func (so *SyslogOutput) Close() error { return so.conn.Close() }
+
+func (so *SyslogOutput) Printf(calldepth int, format string, vset ...interface{}) {
+	level := LevelInfo
+	if anyErr(vset...) {
+		level = LevelError
+	}
+	so.logf(level, format, vset...)
+}
+
+func (so *SyslogOutput) Log(level int, calldepth int, format string, vset ...interface{}) {
+	so.logf(level, format, vset...)
+}
+
+func (so *SyslogOutput) logf(level int, format string, vset ...interface{}) {
+	var msg string
+	if format == "" {
+		msg = fmt.Sprint(vset...)
+	} else {
+		msg = fmt.Sprintf(format, vset...)
+	}
+	if prefix := so.Prefix(); prefix != "" {
+		msg = prefix + ": " + msg
+	}
+
+	pri := so.facility*8 + syslogSeverity(level)
+	var out []byte
+	if so.network == "" {
+		// /dev/log expects a bare "<PRI>tag: msg" line, no RFC 5424 framing.
+		out = []byte(fmt.Sprintf("<%d>%s: %s\n", pri, so.appName, msg))
+	} else {
+		out = []byte(fmt.Sprintf("<%d>1 %s %s %s - - - %s\n",
+			pri, time.Now().UTC().Format(time.RFC3339), so.hostname, so.appName, msg))
+	}
+
+	so.mu.Lock()
+	_, err := so.conn.Write(out)
+	so.mu.Unlock()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "logt: syslog write failed:", err)
+	}
+}